@@ -0,0 +1,41 @@
+//  Copyright 2017 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package distro
+
+// knownMajorVersions lists the major versions of each distro family that gcloud's import
+// tools recognize via FromComponents. It's the source of truth for KnownMajorVersions, and
+// needs a new entry whenever import support for another major version ships.
+var knownMajorVersions = map[string][]string{
+	"centos":  {"7", "8"},
+	"debian":  {"9", "10", "11", "12"},
+	"rhel":    {"6", "7", "8", "9"},
+	"rocky":   {"8", "9"},
+	"sles":    {"12", "15"},
+	"ubuntu":  {"1604", "1804", "2004", "2204", "2404"},
+	"windows": {"2016", "2019", "2022", "2025"},
+}
+
+// KnownMajorVersions returns the major versions of shortName that gcloud's import tools
+// recognize, e.g. KnownMajorVersions("rhel") -> ["6", "7", "8", "9"]. It returns nil for an
+// unrecognized distro family. Callers that rank candidates by how far the detected version
+// is from each of these (e.g. OSVersionCheck.SuggestedOSIDs) get back a fresh slice they're
+// free to sort in place.
+func KnownMajorVersions(shortName string) []string {
+	versions := knownMajorVersions[shortName]
+	if versions == nil {
+		return nil
+	}
+	return append([]string(nil), versions...)
+}
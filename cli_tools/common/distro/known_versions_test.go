@@ -0,0 +1,38 @@
+//  Copyright 2017 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package distro
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKnownMajorVersions(t *testing.T) {
+	if got := KnownMajorVersions("unknown-distro"); got != nil {
+		t.Errorf("KnownMajorVersions(unknown) = %v, want nil", got)
+	}
+
+	got := KnownMajorVersions("rhel")
+	want := []string{"6", "7", "8", "9"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("KnownMajorVersions(rhel) = %v, want %v", got, want)
+	}
+
+	// Mutating the returned slice must not leak back into the package's own table.
+	got[0] = "mutated"
+	if again := KnownMajorVersions("rhel"); !reflect.DeepEqual(again, want) {
+		t.Errorf("KnownMajorVersions(rhel) after caller mutation = %v, want unaffected %v", again, want)
+	}
+}
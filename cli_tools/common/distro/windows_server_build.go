@@ -0,0 +1,75 @@
+//  Copyright 2017 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+// Package distro maps between the OS identifiers osconfig/the mounted disk report and the
+// osIDs gcloud's import tools accept (see FromComponents, defined elsewhere in this
+// package), plus helpers for the Windows-specific cases where that mapping needs more than
+// just the OS name and marketing version.
+package distro
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrWindowsClientSKU is returned by WindowsServerVersionForNTBuild when build identifies a
+// Windows client release (e.g. Windows 10 or 11) rather than a Windows Server release.
+var ErrWindowsClientSKU = errors.New("distro: build number identifies a Windows client release, not Windows Server")
+
+// windowsServerBuilds maps the first CurrentBuild of each Windows Server release that
+// reports NT version 10.0, since that's the only thing that tells them apart.
+var windowsServerBuilds = map[string][2]string{
+	"14393": {"2016", "0"},
+	"17763": {"2019", "0"},
+	"20348": {"2022", "0"},
+	"26100": {"2025", "0"},
+}
+
+// windowsClientOnlyBuilds lists CurrentBuild values known to belong only to Windows client
+// releases (Windows 10 and 11), rather than Windows Server. Builds that Windows Server also
+// shipped under the same number (14393, 17763, 26100) are deliberately absent here; callers
+// that can read InstallationType from the registry should prefer that signal for those.
+var windowsClientOnlyBuilds = map[string]bool{
+	"10240": true,
+	"10586": true,
+	"15063": true,
+	"16299": true,
+	"17134": true,
+	"18362": true,
+	"18363": true,
+	"19041": true,
+	"19042": true,
+	"19043": true,
+	"19044": true,
+	"19045": true,
+	"22000": true,
+	"22621": true,
+	"22631": true,
+}
+
+// WindowsServerVersionForNTBuild maps an NT 10.0 build number to the marketing major/minor
+// version of the Windows Server release it belongs to, e.g. "20348" -> ("2022", "0"). It
+// returns ErrWindowsClientSKU for builds known to belong only to a Windows client release.
+func WindowsServerVersionForNTBuild(major, minor, build string) (string, string, error) {
+	if major != "10" || minor != "0" {
+		return "", "", fmt.Errorf("distro: build-based disambiguation only applies to NT 10.0, got NT %s.%s", major, minor)
+	}
+	if windowsClientOnlyBuilds[build] {
+		return "", "", ErrWindowsClientSKU
+	}
+	if v, ok := windowsServerBuilds[build]; ok {
+		return v[0], v[1], nil
+	}
+	return "", "", fmt.Errorf("distro: unrecognized Windows NT 10.0 build number %q", build)
+}
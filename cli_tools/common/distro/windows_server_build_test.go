@@ -0,0 +1,67 @@
+//  Copyright 2017 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package distro
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWindowsServerVersionForNTBuild(t *testing.T) {
+	tests := []struct {
+		name                 string
+		major, minor, build  string
+		wantMajor, wantMinor string
+		wantErr              error
+	}{
+		{name: "server 2016", major: "10", minor: "0", build: "14393", wantMajor: "2016", wantMinor: "0"},
+		{name: "server 2019", major: "10", minor: "0", build: "17763", wantMajor: "2019", wantMinor: "0"},
+		{name: "server 2022", major: "10", minor: "0", build: "20348", wantMajor: "2022", wantMinor: "0"},
+		{name: "server 2025", major: "10", minor: "0", build: "26100", wantMajor: "2025", wantMinor: "0"},
+		{name: "windows 10 client-only build", major: "10", minor: "0", build: "19045", wantErr: ErrWindowsClientSKU},
+		{name: "windows 11 client-only build", major: "10", minor: "0", build: "22621", wantErr: ErrWindowsClientSKU},
+		{name: "not NT 10.0", major: "6", minor: "3", build: "9600", wantErr: errUnspecified},
+		{name: "unrecognized build", major: "10", minor: "0", build: "99999", wantErr: errUnspecified},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotMajor, gotMinor, err := WindowsServerVersionForNTBuild(tt.major, tt.minor, tt.build)
+			if tt.wantErr == ErrWindowsClientSKU {
+				if !errors.Is(err, ErrWindowsClientSKU) {
+					t.Fatalf("WindowsServerVersionForNTBuild() err = %v, want ErrWindowsClientSKU", err)
+				}
+				return
+			}
+			if tt.wantErr == errUnspecified {
+				if err == nil {
+					t.Fatalf("WindowsServerVersionForNTBuild() err = nil, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("WindowsServerVersionForNTBuild() unexpected err: %v", err)
+			}
+			if gotMajor != tt.wantMajor || gotMinor != tt.wantMinor {
+				t.Errorf("WindowsServerVersionForNTBuild() = (%q, %q), want (%q, %q)",
+					gotMajor, gotMinor, tt.wantMajor, tt.wantMinor)
+			}
+		})
+	}
+}
+
+// errUnspecified is a sentinel used only within this test table to mean "some non-nil,
+// non-ErrWindowsClientSKU error", since the exact wording of those errors isn't part of the
+// contract.
+var errUnspecified = errors.New("unspecified error")
@@ -0,0 +1,82 @@
+//  Copyright 2017 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package precheck
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/osconfig/osinfo"
+
+	"github.com/GoogleCloudPlatform/compute-image-tools/cli_tools/import_precheck/precheck/platforms"
+)
+
+// ArchitectureCompatibilityCheck is a precheck.Check that verifies the disk's CPU
+// architecture can actually run on the requested GCE machine type.
+type ArchitectureCompatibilityCheck struct {
+	OSInfo *osinfo.OSInfo
+
+	// MachineType is the target GCE machine type, e.g. "n1-standard-4". Empty skips
+	// the check, since there's nothing to compare the disk's architecture against.
+	MachineType string
+}
+
+// GetName returns the name of the precheck step; this is shown to the user.
+func (c *ArchitectureCompatibilityCheck) GetName() string {
+	return "Architecture Compatibility Check"
+}
+
+// Run executes the precheck step.
+func (c *ArchitectureCompatibilityCheck) Run() (*Report, error) {
+	r := &Report{name: c.GetName()}
+
+	if c.MachineType == "" {
+		r.Info("No target machine type was provided; skipping architecture compatibility check.")
+		r.result = Skipped
+		return r, nil
+	}
+
+	if c.OSInfo.Architecture == "" {
+		r.Info("Unable to determine the disk's architecture; skipping architecture compatibility check.")
+		r.result = Skipped
+		return r, nil
+	}
+
+	targetOS := "linux"
+	if c.OSInfo.ShortName == osinfo.Windows {
+		targetOS = "windows"
+	}
+
+	matcher, err := platforms.Default(targetOS, c.MachineType)
+	if err != nil {
+		r.Info(err.Error())
+		r.result = Skipped
+		return r, nil
+	}
+
+	disk, err := platforms.Parse(fmt.Sprintf("%s/%s", targetOS, c.OSInfo.Architecture))
+	if err != nil {
+		r.Fatal(err.Error())
+		return r, nil
+	}
+
+	if matcher.Match(disk) {
+		r.Info(fmt.Sprintf("Disk architecture %s is compatible with machine type %s.", disk.Architecture, c.MachineType))
+	} else {
+		r.Fatal(fmt.Sprintf(
+			"Disk architecture %s cannot run on machine type %s. Choose a machine type whose series supports %s.",
+			disk.Architecture, c.MachineType, disk.Architecture))
+	}
+	return r, nil
+}
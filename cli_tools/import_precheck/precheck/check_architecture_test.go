@@ -0,0 +1,99 @@
+//  Copyright 2017 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package precheck
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/osconfig/osinfo"
+)
+
+func TestArchitectureCompatibilityCheck_SkipsWithoutMachineType(t *testing.T) {
+	c := &ArchitectureCompatibilityCheck{OSInfo: &osinfo.OSInfo{Architecture: "x86_64"}}
+	r, err := c.Run()
+	if err != nil {
+		t.Fatalf("Run() unexpected err: %v", err)
+	}
+	if r.result != Skipped {
+		t.Errorf("Run() result = %v, want Skipped when no machine type was given", r.result)
+	}
+}
+
+func TestArchitectureCompatibilityCheck_SkipsWithoutDiskArchitecture(t *testing.T) {
+	c := &ArchitectureCompatibilityCheck{OSInfo: &osinfo.OSInfo{}, MachineType: "n1-standard-4"}
+	r, err := c.Run()
+	if err != nil {
+		t.Fatalf("Run() unexpected err: %v", err)
+	}
+	if r.result != Skipped {
+		t.Errorf("Run() result = %v, want Skipped when the disk architecture is undetected", r.result)
+	}
+}
+
+func TestArchitectureCompatibilityCheck_SkipsOnUnrecognizedMachineType(t *testing.T) {
+	c := &ArchitectureCompatibilityCheck{
+		OSInfo:      &osinfo.OSInfo{Architecture: "x86_64"},
+		MachineType: "unknown-series-1",
+	}
+	r, err := c.Run()
+	if err != nil {
+		t.Fatalf("Run() unexpected err: %v", err)
+	}
+	if r.result != Skipped {
+		t.Errorf("Run() result = %v, want Skipped for an unrecognized machine series", r.result)
+	}
+}
+
+func TestArchitectureCompatibilityCheck_FlagsIncompatibleArchitecture(t *testing.T) {
+	c := &ArchitectureCompatibilityCheck{
+		OSInfo:      &osinfo.OSInfo{Architecture: "aarch64"},
+		MachineType: "n1-standard-4",
+	}
+	r, err := c.Run()
+	if err != nil {
+		t.Fatalf("Run() unexpected err: %v", err)
+	}
+	if r.result == Skipped {
+		t.Errorf("Run() result = Skipped, want a failure result for an arm64 disk on an amd64-only machine type")
+	}
+}
+
+func TestArchitectureCompatibilityCheck_AllowsCompatibleArchitecture(t *testing.T) {
+	c := &ArchitectureCompatibilityCheck{
+		OSInfo:      &osinfo.OSInfo{Architecture: "x86_64"},
+		MachineType: "n1-standard-4",
+	}
+	r, err := c.Run()
+	if err != nil {
+		t.Fatalf("Run() unexpected err: %v", err)
+	}
+	if r.result == Skipped {
+		t.Errorf("Run() result = Skipped, want a pass result for an amd64 disk on an amd64 machine type")
+	}
+}
+
+func TestArchitectureCompatibilityCheck_AllowsCompatibleWindowsDisk(t *testing.T) {
+	c := &ArchitectureCompatibilityCheck{
+		OSInfo:      &osinfo.OSInfo{ShortName: osinfo.Windows, Architecture: "x86_64"},
+		MachineType: "n1-standard-4",
+	}
+	r, err := c.Run()
+	if err != nil {
+		t.Fatalf("Run() unexpected err: %v", err)
+	}
+	if r.result == Skipped {
+		t.Errorf("Run() result = Skipped, want a pass result for an amd64 Windows disk on an amd64 machine type")
+	}
+}
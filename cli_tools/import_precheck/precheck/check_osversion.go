@@ -15,22 +15,46 @@
 package precheck
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/GoogleCloudPlatform/osconfig/osinfo"
 
 	"github.com/GoogleCloudPlatform/compute-image-tools/cli_tools/common/distro"
 	"github.com/GoogleCloudPlatform/compute-image-tools/cli_tools/common/utils/daisyutils"
+	"github.com/GoogleCloudPlatform/compute-image-tools/cli_tools/import_precheck/precheck/osdetect"
 )
 
 const (
 	docsURL = "https://cloud.google.com/sdk/gcloud/reference/compute/images/import"
+
+	// maxSuggestions caps the number of osIDs surfaced by SuggestedOSIDs, so that a
+	// distro with many releases doesn't drown out the closest matches.
+	maxSuggestions = 3
 )
 
 // OSVersionCheck is a precheck.Check that verifies the disk's operating system is importable.
 type OSVersionCheck struct {
 	OSInfo *osinfo.OSInfo
+
+	// OverrideOSID, when non-empty, skips auto-detection entirely and validates this
+	// osID instead. NewChecks populates it from Args.OverrideOSID, which in turn comes
+	// from the `--os` flag's override form, for users whose disk osconfig can't identify
+	// (analogous to skopeo's --override-platform).
+	OverrideOSID string
+
+	// OSReleaseContent, when set, is the contents of /etc/os-release (or
+	// /usr/lib/os-release) read from the mounted disk. It's consulted as a fallback
+	// when osconfig's own detection is empty or generic, since osconfig's cloud-side
+	// inventory is often stale or unavailable for disks that are only now being imported.
+	OSReleaseContent string
+
+	// WindowsRegistry, when set, is an already-opened handle to
+	// osdetect.CurrentVersionKeyPath in the mounted disk's SOFTWARE hive. It's the
+	// Windows analogue of OSReleaseContent.
+	WindowsRegistry osdetect.RegistryKey
 }
 
 // GetName returns the name of the precheck step; this is shown to the user.
@@ -41,12 +65,29 @@ func (c *OSVersionCheck) GetName() string {
 // Run executes the precheck step.
 func (c *OSVersionCheck) Run() (*Report, error) {
 	r := &Report{name: c.GetName()}
-	// Find osID from OS config's detection results.
-	major, minor := splitOSVersion(c.OSInfo.Version)
-	osID := c.createOSID(major, minor, r)
+
+	if c.OverrideOSID != "" {
+		c.runOverride(r)
+		return r, nil
+	}
+
+	resolved, usedFallback := c.resolve()
+	if usedFallback {
+		r.Info(fmt.Sprintf("osconfig detection was inconclusive; falling back to %s found on disk.", resolved.shortName))
+	}
+
+	osID, blocked := c.createOSID(resolved, r)
+	if blocked {
+		// createOSID already recorded a Fatal explaining why, e.g. a Windows client SKU.
+		return r, nil
+	}
 	if osID == "" {
 		r.Info("Unable to determine whether your system is supported for import. " +
 			"For supported versions, see " + docsURL)
+		if suggestions := c.suggestedOSIDsFor(resolved); len(suggestions) > 0 {
+			r.Info(fmt.Sprintf("Did you mean one of: %s? If so, retry with --os=<osID>.",
+				strings.Join(suggestions, ", ")))
+		}
 		r.result = Skipped
 		return r, nil
 	}
@@ -60,10 +101,10 @@ func (c *OSVersionCheck) Run() (*Report, error) {
 		}
 	}
 	if supported {
-		if c.OSInfo.ShortName == osinfo.Windows {
+		if resolved.shortName == osinfo.Windows {
 			// Emit the NT version for Windows, since the same NT version is
 			// either Desktop or Server, and we don't want to emit a misleading message.
-			r.Info(fmt.Sprintf("Detected Windows version number: NT %s", c.OSInfo.Version))
+			r.Info(fmt.Sprintf("Detected Windows version number: NT %s.%s", resolved.major, resolved.minor))
 		} else {
 			r.Info(fmt.Sprintf("Detected system: %s", osID))
 		}
@@ -73,53 +114,249 @@ func (c *OSVersionCheck) Run() (*Report, error) {
 	return r, nil
 }
 
-// createOSID creates the osID, as used in the `--os` flag of the CLI tools. An empty string is
-// return when unable to determine the osID.
-func (c *OSVersionCheck) createOSID(originalMajor string, originalMinor string, r *Report) string {
-	major, minor := originalMajor, originalMinor
+// runOverride validates c.OverrideOSID instead of running auto-detection, and records the
+// outcome on r. Some systems are only available as BYOL, so both osID variants are tried.
+func (c *OSVersionCheck) runOverride(r *Report) {
+	var supported bool
+	for _, suffix := range []string{"", "-byol"} {
+		if daisyutils.ValidateOS(c.OverrideOSID+suffix) == nil {
+			supported = true
+			break
+		}
+	}
+	if !supported {
+		r.Fatal(c.OverrideOSID + " is not a recognized osID. For supported versions, see " + docsURL)
+		return
+	}
+	r.Info(fmt.Sprintf("OS detection overridden with --os=%s", c.OverrideOSID))
+}
+
+// SuggestedOSIDs returns the gcloud osIDs that most closely match the detected OS, ranked by
+// edit distance on the major version component. (distro.KnownMajorVersions only enumerates
+// majors, so minor is constant across candidates and can't contribute to the ranking.) It's
+// meant to give users a concrete value to pass via --os when auto-detection can't pin down
+// an exact match, e.g. "did you mean --os=rhel-8?". Like createOSID, it consults the on-disk
+// fallback detector when osconfig itself reported nothing.
+func (c *OSVersionCheck) SuggestedOSIDs() []string {
+	resolved, _ := c.resolve()
+	return c.suggestedOSIDsFor(resolved)
+}
+
+// suggestedOSIDsFor is SuggestedOSIDs' implementation, factored out so Run can reuse the
+// resolvedOS it already computed for createOSID instead of resolving twice.
+func (c *OSVersionCheck) suggestedOSIDsFor(resolved resolvedOS) []string {
+	if resolved.shortName == "" || resolved.shortName == osinfo.Linux {
+		return nil
+	}
+
+	type candidate struct {
+		osID     string
+		distance int
+	}
+	var candidates []candidate
+	for _, knownMajor := range distro.KnownMajorVersions(resolved.shortName) {
+		release, err := distro.FromComponents(resolved.shortName, knownMajor, resolved.minor, c.OSInfo.Architecture)
+		if err != nil {
+			continue
+		}
+		osID := release.AsGcloudArg()
+		if osID == "" {
+			continue
+		}
+		candidates = append(candidates, candidate{osID: osID, distance: editDistance(resolved.major, knownMajor)})
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].distance < candidates[j].distance })
 
-	switch c.OSInfo.ShortName {
+	var suggestions []string
+	for _, candidate := range candidates {
+		if len(suggestions) == maxSuggestions {
+			break
+		}
+		suggestions = append(suggestions, candidate.osID)
+	}
+	return suggestions
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	distances := make([][]int, len(a)+1)
+	for i := range distances {
+		distances[i] = make([]int, len(b)+1)
+		distances[i][0] = i
+	}
+	for j := range distances[0] {
+		distances[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			if a[i-1] == b[j-1] {
+				distances[i][j] = distances[i-1][j-1]
+				continue
+			}
+			min := distances[i-1][j]
+			if distances[i][j-1] < min {
+				min = distances[i][j-1]
+			}
+			if distances[i-1][j-1] < min {
+				min = distances[i-1][j-1]
+			}
+			distances[i][j] = min + 1
+		}
+	}
+	return distances[len(a)][len(b)]
+}
+
+// resolvedOS is osconfig's detection result, or the on-disk fallback's if osconfig's own
+// was empty or too generic to act on. createOSID, Run, and SuggestedOSIDs all derive their
+// answer from the same resolvedOS, so a fallback result always reaches every caller instead
+// of only the one that happened to resolve it first.
+type resolvedOS struct {
+	shortName string
+	major     string
+	minor     string
+	build     string
+
+	// productName and installationType are only ever populated by the Windows registry
+	// fallback (osconfig doesn't report them); installationType is "Client" for desktop
+	// SKUs (e.g. Windows 10/11) and "Server" for server SKUs.
+	productName      string
+	installationType string
+}
+
+// resolve determines the OS to check, consulting the on-disk fallback detector when
+// osconfig's own report is empty or generic. usedFallback is true when the fallback
+// detector's result was used, so Run can log why.
+func (c *OSVersionCheck) resolve() (resolved resolvedOS, usedFallback bool) {
+	major, minor, build := splitOSVersion(c.OSInfo.Version)
+	shortName := c.OSInfo.ShortName
+
+	if shortName == "" || shortName == osinfo.Linux {
+		if fallback, ok := c.detectFromDisk(); ok {
+			fallbackBuild := fallback.Build
+			if fallbackBuild == "" {
+				fallbackBuild = build
+			}
+			return resolvedOS{
+				shortName:        fallback.ShortName,
+				major:            fallback.Major,
+				minor:            fallback.Minor,
+				build:            fallbackBuild,
+				productName:      fallback.ProductName,
+				installationType: fallback.InstallationType,
+			}, true
+		}
+	}
+	return resolvedOS{shortName: shortName, major: major, minor: minor, build: build}, false
+}
+
+// createOSID creates the osID, as used in the `--os` flag of the CLI tools, from resolved.
+// An empty string is returned when unable to determine the osID. blocked is true when Run
+// should stop immediately because a Fatal has already been recorded on r (e.g. a Windows
+// client SKU).
+func (c *OSVersionCheck) createOSID(resolved resolvedOS, r *Report) (osID string, blocked bool) {
+	shortName, major, minor := resolved.shortName, resolved.major, resolved.minor
+
+	switch shortName {
 	case "":
 		r.Info("Unable to determine OS.")
-		return ""
+		return "", false
 	case osinfo.Linux:
 		// OS config returns "linux" as the distro when it can't find a more specific match.
 		r.Info("Detected generic Linux system.")
-		return ""
+		return "", false
 	case osinfo.Windows:
 		r.Info("Detected Windows system.")
-		// OS config uses NT version numbers, while cli_tools/common/distro uses marketing verions.
+		// The registry fallback's InstallationType is a direct, self-describing signal
+		// for client SKUs, unlike the build-number table below, which only covers the
+		// client/server split indirectly and needs a manual update for every release.
+		if resolved.installationType == "Client" {
+			name := resolved.productName
+			if name == "" {
+				name = "This disk"
+			}
+			r.Fatal(fmt.Sprintf(
+				"%s is a Windows client release and is not supported for import.", name))
+			return "", true
+		}
+		// Windows Server 2016, 2019, 2022, and 2025 all report NT version 10.0; the
+		// build number is the only thing that tells them apart, so prefer it over the
+		// NT major.minor lookup whenever osconfig or the registry fallback reported one.
+		if resolved.build != "" {
+			buildMajor, buildMinor, err := distro.WindowsServerVersionForNTBuild(major, minor, resolved.build)
+			if err == nil {
+				major, minor = buildMajor, buildMinor
+				break
+			}
+			if errors.Is(err, distro.ErrWindowsClientSKU) {
+				r.Fatal(fmt.Sprintf(
+					"Windows build %s is a client release (e.g. Windows 10 or 11) and is not supported for import.",
+					resolved.build))
+				return "", true
+			}
+		}
+		// OS config uses NT version numbers, while cli_tools/common/distro uses marketing
+		// versions.
 		windowsMajor, windowsMinor, err :=
-			distro.WindowsServerVersionforNTVersion(originalMajor, originalMinor)
+			distro.WindowsServerVersionforNTVersion(major, minor)
 		if err == nil {
 			major, minor = windowsMajor, windowsMinor
 		}
 	}
 
-	release, err := distro.FromComponents(c.OSInfo.ShortName, major, minor, c.OSInfo.Architecture)
+	release, err := distro.FromComponents(shortName, major, minor, c.OSInfo.Architecture)
 	if err != nil {
 		r.Info(err.Error())
-		return ""
+		return "", false
 	}
-	osID := release.AsGcloudArg()
+	osID = release.AsGcloudArg()
 	if osID != "" {
-		return osID
+		return osID, false
 	}
 	// If the distro package can't determine the osID, attempt to create one using
 	// the format "os-version".
-	if c.OSInfo.ShortName != osinfo.Linux && c.OSInfo.ShortName != "" && c.OSInfo.Version != "" {
-		return fmt.Sprintf("%s-%s", c.OSInfo.ShortName, c.OSInfo.Version)
+	if shortName != osinfo.Linux && shortName != "" && resolved.major != "" {
+		version := resolved.major
+		if resolved.minor != "" {
+			version += "." + resolved.minor
+		}
+		return fmt.Sprintf("%s-%s", shortName, version), false
+	}
+	return "", false
+}
+
+// detectFromDisk attempts the fallback, on-disk detection layer: parsing /etc/os-release
+// for Linux, or reading the CurrentVersion registry key for Windows. It returns false when
+// neither source was provided by the caller, which is the common case when the disk wasn't
+// mounted (e.g. a dry-run, or a precheck run before disk attachment).
+func (c *OSVersionCheck) detectFromDisk() (osdetect.Info, bool) {
+	if c.OSReleaseContent != "" {
+		if info, ok := osdetect.FromOSRelease(c.OSReleaseContent); ok {
+			return info, true
+		}
+	}
+	if c.WindowsRegistry != nil {
+		if info, ok := osdetect.FromWindowsRegistry(c.WindowsRegistry); ok {
+			return info, true
+		}
 	}
-	return ""
+	return osdetect.Info{}, false
 }
 
-func splitOSVersion(version string) (major, minor string) {
+// splitOSVersion splits a dotted version string into its major, minor, and build
+// components. Build is kept (rather than discarded, as a two-component split would)
+// because Windows Server 2016 through 2025 are only distinguishable by build number once
+// major.minor has collapsed to "10.0".
+func splitOSVersion(version string) (major, minor, build string) {
 	if version == "" {
-		return "", ""
+		return "", "", ""
 	}
-	if !strings.Contains(version, ".") {
-		return version, ""
+	parts := strings.SplitN(version, ".", 3)
+	switch len(parts) {
+	case 1:
+		return parts[0], "", ""
+	case 2:
+		return parts[0], parts[1], ""
+	default:
+		return parts[0], parts[1], parts[2]
 	}
-	parts := strings.Split(version, ".")
-	return parts[0], parts[1]
 }
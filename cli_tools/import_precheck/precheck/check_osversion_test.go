@@ -0,0 +1,90 @@
+//  Copyright 2017 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package precheck
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/osconfig/osinfo"
+)
+
+func TestSplitOSVersion(t *testing.T) {
+	tests := []struct {
+		version             string
+		major, minor, build string
+	}{
+		{version: "", major: "", minor: "", build: ""},
+		{version: "8", major: "8", minor: "", build: ""},
+		{version: "8.4", major: "8", minor: "4", build: ""},
+		{version: "10.0.20348", major: "10", minor: "0", build: "20348"},
+		{version: "10.0.19041.1.2", major: "10", minor: "0", build: "19041.1.2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			major, minor, build := splitOSVersion(tt.version)
+			if major != tt.major || minor != tt.minor || build != tt.build {
+				t.Errorf("splitOSVersion(%q) = (%q, %q, %q), want (%q, %q, %q)",
+					tt.version, major, minor, build, tt.major, tt.minor, tt.build)
+			}
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "8", b: "8", want: 0},
+		{a: "8", b: "9", want: 1},
+		{a: "7", b: "10", want: 2},
+		{a: "", b: "10", want: 2},
+		{a: "2019", b: "2022", want: 2},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestResolve_PassesThroughOSConfigResultWhenNotGeneric(t *testing.T) {
+	c := &OSVersionCheck{
+		OSInfo: &osinfo.OSInfo{ShortName: "rhel", Version: "8.4"},
+	}
+	resolved, usedFallback := c.resolve()
+	if usedFallback {
+		t.Errorf("resolve() usedFallback = true, want false when osconfig already identified the OS")
+	}
+	want := resolvedOS{shortName: "rhel", major: "8", minor: "4"}
+	if resolved != want {
+		t.Errorf("resolve() = %+v, want %+v", resolved, want)
+	}
+}
+
+func TestResolve_FallsBackToOSRelease(t *testing.T) {
+	c := &OSVersionCheck{
+		OSInfo:           &osinfo.OSInfo{ShortName: osinfo.Linux},
+		OSReleaseContent: "ID=debian\nVERSION_ID=11\n",
+	}
+	resolved, usedFallback := c.resolve()
+	if !usedFallback {
+		t.Fatalf("resolve() usedFallback = false, want true when osconfig only reported generic linux")
+	}
+	want := resolvedOS{shortName: "debian", major: "11"}
+	if resolved != want {
+		t.Errorf("resolve() = %+v, want %+v", resolved, want)
+	}
+}
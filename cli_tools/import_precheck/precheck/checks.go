@@ -0,0 +1,63 @@
+//  Copyright 2017 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package precheck
+
+import (
+	"github.com/GoogleCloudPlatform/osconfig/osinfo"
+
+	"github.com/GoogleCloudPlatform/compute-image-tools/cli_tools/import_precheck/precheck/osdetect"
+)
+
+// Args bundles the inputs the import tools gather (from CLI flags and from the mounted
+// disk) that the individual Checks need. It exists so that adding a new Check's input
+// doesn't change NewChecks' signature for every caller.
+//
+// None of these fields are set by this package: the import tools' CLI flag parsing and
+// disk-mounting code, which would populate them, are out of scope for this series and live
+// outside the precheck package. A caller that wants OverrideOSID, the on-disk fallback
+// detectors, or MachineType populated needs to wire its own `--os`/`--machine-type` flags
+// and disk access and construct an Args value from them before calling NewChecks.
+type Args struct {
+	OSInfo *osinfo.OSInfo
+
+	// OverrideOSID is the `--os` flag's override form; see OSVersionCheck.OverrideOSID.
+	OverrideOSID string
+
+	// OSReleaseContent and WindowsRegistry are the on-disk fallback detection sources;
+	// see OSVersionCheck.OSReleaseContent and OSVersionCheck.WindowsRegistry.
+	OSReleaseContent string
+	WindowsRegistry  osdetect.RegistryKey
+
+	// MachineType is the target GCE machine type from `--machine-type`; see
+	// ArchitectureCompatibilityCheck.MachineType.
+	MachineType string
+}
+
+// NewChecks returns the Checks the import tools run against a mounted disk, in the order
+// they're reported to the user.
+func NewChecks(args Args) []Check {
+	return []Check{
+		&OSVersionCheck{
+			OSInfo:           args.OSInfo,
+			OverrideOSID:     args.OverrideOSID,
+			OSReleaseContent: args.OSReleaseContent,
+			WindowsRegistry:  args.WindowsRegistry,
+		},
+		&ArchitectureCompatibilityCheck{
+			OSInfo:      args.OSInfo,
+			MachineType: args.MachineType,
+		},
+	}
+}
@@ -0,0 +1,83 @@
+//  Copyright 2017 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package osdetect
+
+import "strings"
+
+// FromOSRelease parses the contents of /etc/os-release (or /usr/lib/os-release, its
+// fallback location), the same file snapd's release package consumes to identify the
+// host distro. It understands the shell-like assignments the file is specified to
+// contain: `KEY=value`, `KEY="quoted value"`, `KEY='quoted value'`, blank lines, and `#`
+// comments.
+//
+// ID and VERSION_ID are used to build Info. When ID is absent, the first entry of
+// ID_LIKE (a space-separated list of related distros, e.g. "ubuntu debian") is used as a
+// best-effort substitute, since that's still enough for distro.FromComponents to resolve
+// a family of releases.
+func FromOSRelease(content string) (Info, bool) {
+	fields := parseOSReleaseFields(content)
+
+	id := fields["ID"]
+	if id == "" {
+		if idLike := fields["ID_LIKE"]; idLike != "" {
+			id = strings.Fields(idLike)[0]
+		}
+	}
+	if id == "" {
+		return Info{}, false
+	}
+
+	major, minor := splitVersionID(fields["VERSION_ID"])
+	return Info{ShortName: id, Major: major, Minor: minor}, true
+}
+
+func parseOSReleaseFields(content string) map[string]string {
+	fields := map[string]string{}
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+		fields[strings.TrimSpace(key)] = unquote(strings.TrimSpace(value))
+	}
+	return fields
+}
+
+// unquote strips a single layer of matching double or single quotes, as permitted by the
+// os-release format.
+func unquote(value string) string {
+	if len(value) >= 2 {
+		first, last := value[0], value[len(value)-1]
+		if (first == '"' && last == '"') || (first == '\'' && last == '\'') {
+			return value[1 : len(value)-1]
+		}
+	}
+	return value
+}
+
+func splitVersionID(versionID string) (major, minor string) {
+	if versionID == "" {
+		return "", ""
+	}
+	parts := strings.SplitN(versionID, ".", 2)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
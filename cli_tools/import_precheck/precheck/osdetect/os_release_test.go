@@ -0,0 +1,72 @@
+//  Copyright 2017 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package osdetect
+
+import "testing"
+
+func TestFromOSRelease(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    Info
+		wantOK  bool
+	}{
+		{
+			name: "id and version_id",
+			content: "NAME=\"Ubuntu\"\n" +
+				"ID=ubuntu\n" +
+				"VERSION_ID=\"20.04\"\n",
+			want:   Info{ShortName: "ubuntu", Major: "20", Minor: "04"},
+			wantOK: true,
+		},
+		{
+			name: "single-quoted values and comments",
+			content: "# a comment\n" +
+				"ID='rhel'\n" +
+				"VERSION_ID='8'\n",
+			want:   Info{ShortName: "rhel", Major: "8"},
+			wantOK: true,
+		},
+		{
+			name:    "falls back to first ID_LIKE entry when ID is absent",
+			content: "ID_LIKE=\"debian ubuntu\"\nVERSION_ID=11\n",
+			want:    Info{ShortName: "debian", Major: "11"},
+			wantOK:  true,
+		},
+		{
+			name:    "no ID or ID_LIKE",
+			content: "NAME=\"Unknown\"\n",
+			want:    Info{},
+			wantOK:  false,
+		},
+		{
+			name:    "empty content",
+			content: "",
+			want:    Info{},
+			wantOK:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := FromOSRelease(tt.content)
+			if ok != tt.wantOK {
+				t.Fatalf("FromOSRelease() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if got != tt.want {
+				t.Errorf("FromOSRelease() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
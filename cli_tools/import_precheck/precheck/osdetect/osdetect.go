@@ -0,0 +1,39 @@
+//  Copyright 2017 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+// Package osdetect provides a fallback OS detection layer for disks whose cloud-side
+// osconfig inventory is stale, absent, or too generic (e.g. ShortName "linux"). It reads
+// the same on-disk signals the OS itself uses to identify its version, rather than relying
+// on osconfig having already reported to the service.
+package osdetect
+
+// Info is the result of a fallback detection attempt. It mirrors the subset of
+// osinfo.OSInfo that OSVersionCheck.createOSID consumes, plus the extra fields that are
+// only recoverable by reading the disk directly.
+type Info struct {
+	ShortName string
+	Major     string
+	Minor     string
+
+	// Build is the Windows build number (e.g. "20348"), populated only by
+	// FromWindowsRegistry. It's needed to disambiguate Windows Server releases that
+	// share the same NT major.minor (10.0 covers Server 2016 through 2025).
+	Build string
+
+	// ProductName and InstallationType, populated only by FromWindowsRegistry, let
+	// OSVersionCheck.createOSID recognize and reject client SKUs (e.g. "Windows 10 Pro",
+	// InstallationType "Client") that aren't importable.
+	ProductName      string
+	InstallationType string
+}
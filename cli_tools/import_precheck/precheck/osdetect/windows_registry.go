@@ -0,0 +1,57 @@
+//  Copyright 2017 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package osdetect
+
+import "strconv"
+
+// CurrentVersionKeyPath is the registry path, relative to the SOFTWARE hive root, that
+// holds Windows' own record of its version. It's the same key Windows Setup and WMI read
+// from. Callers of FromWindowsRegistry are expected to open this path in the disk's
+// mounted SOFTWARE hive before calling in.
+const CurrentVersionKeyPath = `Microsoft\Windows NT\CurrentVersion`
+
+// RegistryKey is the minimal accessor osdetect needs from an offline SOFTWARE hive. The
+// caller owns mounting the disk and opening CurrentVersionKeyPath within it (offline hive
+// parsing is a separate concern with its own choice of library); this keeps osdetect
+// agnostic to how that hive was opened.
+type RegistryKey interface {
+	GetStringValue(name string) (value string, err error)
+	GetIntegerValue(name string) (value uint64, err error)
+}
+
+// FromWindowsRegistry derives Info from an already-opened currentVersionKey. It reads
+// ProductName, CurrentMajorVersionNumber, CurrentMinorVersionNumber, CurrentBuild, and
+// InstallationType, the same values Windows itself exposes through WMI's
+// Win32_OperatingSystem. Returns false if the major version number can't be read, since
+// that's the minimum needed to identify the OS at all.
+func FromWindowsRegistry(key RegistryKey) (Info, bool) {
+	major, err := key.GetIntegerValue("CurrentMajorVersionNumber")
+	if err != nil {
+		return Info{}, false
+	}
+	minor, _ := key.GetIntegerValue("CurrentMinorVersionNumber")
+	build, _ := key.GetStringValue("CurrentBuild")
+	productName, _ := key.GetStringValue("ProductName")
+	installationType, _ := key.GetStringValue("InstallationType")
+
+	return Info{
+		ShortName:        "windows",
+		Major:            strconv.FormatUint(major, 10),
+		Minor:            strconv.FormatUint(minor, 10),
+		Build:            build,
+		ProductName:      productName,
+		InstallationType: installationType,
+	}, true
+}
@@ -0,0 +1,79 @@
+//  Copyright 2017 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package osdetect
+
+import (
+	"errors"
+	"testing"
+)
+
+// fakeRegistryKey is an in-memory RegistryKey for testing, standing in for an offline
+// SOFTWARE hive.
+type fakeRegistryKey struct {
+	strings map[string]string
+	ints    map[string]uint64
+}
+
+func (f fakeRegistryKey) GetStringValue(name string) (string, error) {
+	if v, ok := f.strings[name]; ok {
+		return v, nil
+	}
+	return "", errors.New("value not found")
+}
+
+func (f fakeRegistryKey) GetIntegerValue(name string) (uint64, error) {
+	if v, ok := f.ints[name]; ok {
+		return v, nil
+	}
+	return 0, errors.New("value not found")
+}
+
+func TestFromWindowsRegistry(t *testing.T) {
+	key := fakeRegistryKey{
+		strings: map[string]string{
+			"CurrentBuild":     "20348",
+			"ProductName":      "Windows Server 2022 Standard",
+			"InstallationType": "Server",
+		},
+		ints: map[string]uint64{
+			"CurrentMajorVersionNumber": 10,
+			"CurrentMinorVersionNumber": 0,
+		},
+	}
+
+	got, ok := FromWindowsRegistry(key)
+	if !ok {
+		t.Fatalf("FromWindowsRegistry() ok = false, want true")
+	}
+	want := Info{
+		ShortName:        "windows",
+		Major:            "10",
+		Minor:            "0",
+		Build:            "20348",
+		ProductName:      "Windows Server 2022 Standard",
+		InstallationType: "Server",
+	}
+	if got != want {
+		t.Errorf("FromWindowsRegistry() = %+v, want %+v", got, want)
+	}
+}
+
+func TestFromWindowsRegistry_MissingMajorVersion(t *testing.T) {
+	key := fakeRegistryKey{}
+
+	if _, ok := FromWindowsRegistry(key); ok {
+		t.Errorf("FromWindowsRegistry() ok = true, want false when major version is unreadable")
+	}
+}
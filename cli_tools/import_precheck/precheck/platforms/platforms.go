@@ -0,0 +1,142 @@
+//  Copyright 2017 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+// Package platforms implements platform specification and matching, modeled on
+// containerd's platforms package: a disk has a Platform, a target (here, a GCE machine
+// type) accepts a set of Platforms, and a Matcher decides compatibility between the two.
+package platforms
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Platform identifies an OS/architecture/variant triple, following the same model as
+// the OCI image-spec's platform object.
+type Platform struct {
+	OS           string
+	Architecture string
+	Variant      string
+}
+
+// String formats p as the "os/arch[/variant]" specifier Parse accepts.
+func (p Platform) String() string {
+	if p.Variant == "" {
+		return fmt.Sprintf("%s/%s", p.OS, p.Architecture)
+	}
+	return fmt.Sprintf("%s/%s/%s", p.OS, p.Architecture, p.Variant)
+}
+
+// Parse parses a platform specifier of the form "os/arch[/variant]", normalizing
+// uname-style architecture names to their GOARCH-style equivalents the way containerd's
+// platforms.Normalize does (e.g. "x86_64" -> "amd64", "aarch64" -> "arm64").
+func Parse(specifier string) (Platform, error) {
+	parts := strings.Split(specifier, "/")
+	if len(parts) < 2 || len(parts) > 3 {
+		return Platform{}, fmt.Errorf("platforms: %q is not a valid platform specifier, want os/arch[/variant]", specifier)
+	}
+	p := Platform{OS: strings.ToLower(parts[0]), Architecture: strings.ToLower(parts[1])}
+	if len(parts) == 3 {
+		p.Variant = strings.ToLower(parts[2])
+	}
+	normalize(&p)
+	return p, nil
+}
+
+// normalize rewrites the architecture names reported by `uname -m` into the GOARCH-style
+// names used throughout this package.
+func normalize(p *Platform) {
+	switch p.Architecture {
+	case "x86_64":
+		p.Architecture = "amd64"
+	case "aarch64":
+		p.Architecture = "arm64"
+	case "armv7l":
+		p.Architecture = "arm"
+		if p.Variant == "" {
+			p.Variant = "v7"
+		}
+	case "i686":
+		p.Architecture = "386"
+	}
+}
+
+// Matcher reports whether a Platform is compatible with some target.
+type Matcher interface {
+	// Match reports whether p can run on the matcher's target.
+	Match(p Platform) bool
+}
+
+// matcher matches platforms against a single target Platform.
+type matcher struct {
+	target Platform
+}
+
+// NewMatcher returns a Matcher for the given target platform. OS and Architecture must
+// match exactly. Variant must match only when the target specifies one; a target with no
+// variant accepts any variant of its architecture (e.g. linux/arm64 accepts linux/arm64/v8).
+func NewMatcher(target Platform) Matcher {
+	return matcher{target: target}
+}
+
+// Match implements Matcher.
+func (m matcher) Match(p Platform) bool {
+	if p.OS != m.target.OS || p.Architecture != m.target.Architecture {
+		return false
+	}
+	if m.target.Variant == "" {
+		return true
+	}
+	return p.Variant == m.target.Variant
+}
+
+// seriesArchitecture maps a GCE machine series (the prefix of a machine type before its
+// first hyphen, e.g. "n1" in "n1-standard-4") to the CPU architecture it runs.
+var seriesArchitecture = map[string]string{
+	"t2a": "arm64",
+	"c4a": "arm64",
+	"e2":  "amd64",
+	"n1":  "amd64",
+	"n2":  "amd64",
+	"n2d": "amd64",
+	"n4":  "amd64",
+	"t2d": "amd64",
+	"c2":  "amd64",
+	"c2d": "amd64",
+	"c3":  "amd64",
+	"c3d": "amd64",
+	"c4":  "amd64",
+	"c4d": "amd64",
+	"m1":  "amd64",
+	"m2":  "amd64",
+	"m3":  "amd64",
+	"a2":  "amd64",
+	"a3":  "amd64",
+	"g2":  "amd64",
+}
+
+// Default returns the Matcher for the CPU architecture that machineType's series runs, for
+// a disk running targetOS, e.g. Default("linux", "t2a-standard-4") matches linux/arm64. It
+// returns an error for a machine type whose series isn't recognized, rather than guessing.
+func Default(targetOS, machineType string) (Matcher, error) {
+	series := machineType
+	if i := strings.Index(machineType, "-"); i >= 0 {
+		series = machineType[:i]
+	}
+	arch, ok := seriesArchitecture[strings.ToLower(series)]
+	if !ok {
+		return nil, fmt.Errorf("platforms: unrecognized machine type %q", machineType)
+	}
+	return NewMatcher(Platform{OS: targetOS, Architecture: arch}), nil
+}
@@ -0,0 +1,103 @@
+//  Copyright 2017 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package platforms
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		specifier string
+		want      Platform
+		wantErr   bool
+	}{
+		{specifier: "linux/amd64", want: Platform{OS: "linux", Architecture: "amd64"}},
+		{specifier: "linux/x86_64", want: Platform{OS: "linux", Architecture: "amd64"}},
+		{specifier: "linux/aarch64", want: Platform{OS: "linux", Architecture: "arm64"}},
+		{specifier: "linux/armv7l", want: Platform{OS: "linux", Architecture: "arm", Variant: "v7"}},
+		{specifier: "linux/i686", want: Platform{OS: "linux", Architecture: "386"}},
+		{specifier: "Linux/ARM64/V8", want: Platform{OS: "linux", Architecture: "arm64", Variant: "v8"}},
+		{specifier: "linux", wantErr: true},
+		{specifier: "linux/arm64/v8/extra", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.specifier, func(t *testing.T) {
+			got, err := Parse(tt.specifier)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Parse(%q) err = nil, want error", tt.specifier)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q) unexpected err: %v", tt.specifier, err)
+			}
+			if got != tt.want {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.specifier, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatcher_Match(t *testing.T) {
+	m := NewMatcher(Platform{OS: "linux", Architecture: "arm64"})
+
+	if !m.Match(Platform{OS: "linux", Architecture: "arm64"}) {
+		t.Errorf("Match() = false, want true for an exact match")
+	}
+	if !m.Match(Platform{OS: "linux", Architecture: "arm64", Variant: "v8"}) {
+		t.Errorf("Match() = false, want true when the target has no variant requirement")
+	}
+	if m.Match(Platform{OS: "linux", Architecture: "amd64"}) {
+		t.Errorf("Match() = true, want false for a mismatched architecture")
+	}
+	if m.Match(Platform{OS: "windows", Architecture: "arm64"}) {
+		t.Errorf("Match() = true, want false for a mismatched OS")
+	}
+}
+
+func TestDefault(t *testing.T) {
+	tests := []struct {
+		targetOS    string
+		machineType string
+		wantArch    string
+		wantErr     bool
+	}{
+		{targetOS: "linux", machineType: "n1-standard-4", wantArch: "amd64"},
+		{targetOS: "linux", machineType: "t2a-standard-4", wantArch: "arm64"},
+		{targetOS: "linux", machineType: "N2-standard-8", wantArch: "amd64"},
+		{targetOS: "windows", machineType: "n1-standard-4", wantArch: "amd64"},
+		{targetOS: "linux", machineType: "unknown-series-1", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.targetOS+"/"+tt.machineType, func(t *testing.T) {
+			matcher, err := Default(tt.targetOS, tt.machineType)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Default(%q, %q) err = nil, want error", tt.targetOS, tt.machineType)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Default(%q, %q) unexpected err: %v", tt.targetOS, tt.machineType, err)
+			}
+			if !matcher.Match(Platform{OS: tt.targetOS, Architecture: tt.wantArch}) {
+				t.Errorf("Default(%q, %q) matcher doesn't accept %s/%s", tt.targetOS, tt.machineType, tt.targetOS, tt.wantArch)
+			}
+			if matcher.Match(Platform{OS: "bogus-os", Architecture: tt.wantArch}) {
+				t.Errorf("Default(%q, %q) matcher accepts an unrelated OS", tt.targetOS, tt.machineType)
+			}
+		})
+	}
+}